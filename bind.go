@@ -0,0 +1,204 @@
+package blitzhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Context bundles a request's ResponseWriter and *http.Request into one arg
+type Context struct {
+	W http.ResponseWriter
+	R *http.Request
+}
+
+// HandlerProvider adapts a handler value of some recognized shape into an
+// http.Handler, returning ok=false if it doesn't recognize handler's shape.
+type HandlerProvider func(handler interface{}) (http.Handler, bool)
+
+var handlerProviders []HandlerProvider
+
+// RegisterHandlerType adds a HandlerProvider to the registry, tried in
+// registration order; the first to return ok=true wins.
+func RegisterHandlerType(p HandlerProvider) {
+	handlerProviders = append(handlerProviders, p)
+}
+
+func init() {
+	RegisterHandlerType(adaptStdHandler)
+	RegisterHandlerType(adaptContextHandler)
+	RegisterHandlerType(adaptBoundHandler)
+}
+
+// adaptHandler turns handler into an http.Handler using the registered
+// providers, panicking at registration time if none recognize its shape.
+func adaptHandler(handler interface{}) http.Handler {
+	for _, p := range handlerProviders {
+		if h, ok := p(handler); ok {
+			return h
+		}
+	}
+	panic(fmt.Sprintf("blitzhttp: unrecognized handler type %T", handler))
+}
+
+// adaptStdHandler covers plain net/http handlers: http.Handler (which
+// includes http.HandlerFunc) and bare func(w, r) literals.
+func adaptStdHandler(handler interface{}) (http.Handler, bool) {
+	switch h := handler.(type) {
+	case http.Handler:
+		return h, true
+	case func(http.ResponseWriter, *http.Request):
+		return http.HandlerFunc(h), true
+	}
+	return nil, false
+}
+
+// adaptContextHandler covers func(*Context) error handlers.
+func adaptContextHandler(handler interface{}) (http.Handler, bool) {
+	fn, ok := handler.(func(*Context) error)
+	if !ok {
+		return nil, false
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := fn(&Context{W: w, R: r}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}), true
+}
+
+var (
+	responseWriterType = reflect.TypeOf((*http.ResponseWriter)(nil)).Elem()
+	requestType        = reflect.TypeOf((*http.Request)(nil))
+)
+
+// adaptBoundHandler covers func(w, r, *T) for any struct type T, validating
+// the signature once here at registration time.
+func adaptBoundHandler(handler interface{}) (http.Handler, bool) {
+	v := reflect.ValueOf(handler)
+	t := v.Type()
+	if t.Kind() != reflect.Func || t.NumIn() != 3 || t.NumOut() != 0 {
+		return nil, false
+	}
+	if !t.In(0).Implements(responseWriterType) || t.In(1) != requestType {
+		return nil, false
+	}
+	formType := t.In(2)
+	if formType.Kind() != reflect.Ptr || formType.Elem().Kind() != reflect.Struct {
+		return nil, false
+	}
+	structType := formType.Elem()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		form := reflect.New(structType)
+		if err := bindRequest(r, form.Interface()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := validateStruct(form.Interface()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		v.Call([]reflect.Value{reflect.ValueOf(w), reflect.ValueOf(r), form})
+	}), true
+}
+
+// Bind decodes and validates a value of type T from r, for handlers using
+// the func(*Context) error shape instead of the bound (w, r, *T) one.
+func Bind[T any](r *http.Request) (*T, error) {
+	v := new(T)
+	if err := bindRequest(r, v); err != nil {
+		return nil, err
+	}
+	if err := validateStruct(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// bindRequest populates dst (a pointer to struct) from r's JSON body or
+// form values, then from the route's URL params.
+func bindRequest(r *http.Request, dst interface{}) error {
+	if ct := r.Header.Get("Content-Type"); strings.HasPrefix(ct, "application/json") {
+		if r.Body != nil {
+			dec := json.NewDecoder(r.Body)
+			if err := dec.Decode(dst); err != nil && err != io.EOF {
+				return err
+			}
+		}
+	} else if err := r.ParseForm(); err == nil {
+		bindValues(dst, "form", r.Form)
+	}
+	params := RouteParams(r)
+	if len(params) > 0 {
+		values := make(map[string][]string, len(params))
+		for _, p := range params {
+			values[p.Key] = append(values[p.Key], p.Value)
+		}
+		bindValues(dst, "param", values)
+	}
+	return nil
+}
+
+// bindValues sets fields of dst (a pointer to struct) whose tagName tag
+// (falling back to the field name) is present in values.
+func bindValues(dst interface{}, tagName string, values map[string][]string) {
+	rv := reflect.ValueOf(dst).Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		key := field.Tag.Get(tagName)
+		if key == "" {
+			key = field.Name
+		}
+		raw, ok := values[key]
+		if !ok || len(raw) == 0 {
+			continue
+		}
+		setFieldValue(rv.Field(i), raw[0])
+	}
+}
+
+// setFieldValue assigns raw to field if its kind is supported, ignoring
+// values that don't parse.
+func setFieldValue(field reflect.Value, raw string) {
+	if !field.CanSet() {
+		return
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			field.SetInt(n)
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(raw, 64); err == nil {
+			field.SetFloat(f)
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(raw); err == nil {
+			field.SetBool(b)
+		}
+	}
+}
+
+// validateStruct checks dst's `validate:"required"` tags, returning the
+// first failing field.
+func validateStruct(dst interface{}) error {
+	rv := reflect.ValueOf(dst).Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.Tag.Get("validate") != "required" {
+			continue
+		}
+		if rv.Field(i).IsZero() {
+			return fmt.Errorf("blitzhttp: field %q is required", field.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package blitzhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type bindTestForm struct {
+	ID   int    `param:"id"`
+	Name string `form:"name" validate:"required"`
+}
+
+func TestBindRequestFromFormAndParams(t *testing.T) {
+	body := url.Values{"name": {"ada"}}
+	req := httptest.NewRequest(http.MethodPost, "/users/42", strings.NewReader(body.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req = req.WithContext(context.WithValue(req.Context(), paramsKey, Params{{Key: "id", Value: "42"}}))
+
+	var form bindTestForm
+	if err := bindRequest(req, &form); err != nil {
+		t.Fatalf("bindRequest: %v", err)
+	}
+	if form.ID != 42 {
+		t.Errorf("ID = %d, want 42", form.ID)
+	}
+	if form.Name != "ada" {
+		t.Errorf("Name = %q, want ada", form.Name)
+	}
+}
+
+func TestBindRequestFromJSON(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(`{"Name":"grace"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	var form bindTestForm
+	if err := bindRequest(req, &form); err != nil {
+		t.Fatalf("bindRequest: %v", err)
+	}
+	if form.Name != "grace" {
+		t.Errorf("Name = %q, want grace", form.Name)
+	}
+}
+
+func TestValidateStructRequired(t *testing.T) {
+	form := bindTestForm{}
+	if err := validateStruct(&form); err == nil {
+		t.Fatal("want an error for a missing required field")
+	}
+
+	form.Name = "ada"
+	if err := validateStruct(&form); err != nil {
+		t.Fatalf("validateStruct: %v", err)
+	}
+}
+
+func TestAdaptBoundHandlerShortCircuitsOnValidationError(t *testing.T) {
+	called := false
+	handler := func(w http.ResponseWriter, r *http.Request, form *bindTestForm) {
+		called = true
+	}
+
+	h, ok := adaptBoundHandler(handler)
+	if !ok {
+		t.Fatal("adaptBoundHandler: want ok=true for a (w, r, *T) handler")
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/users", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if called {
+		t.Fatal("handler should not run when required-field validation fails")
+	}
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,117 @@
+package blitzhttp
+
+import (
+	"net/http"
+	"strings"
+)
+
+// CleanPath lexically cleans an absolute path: it collapses duplicate
+// slashes and resolves "." and inner ".." segments without ever escaping
+// above the root. The result always starts with "/"; a trailing slash on
+// p (other than the root itself) is preserved.
+func CleanPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	segments := make([]string, 0, strings.Count(p, "/")+1)
+	for _, seg := range strings.Split(p, "/") {
+		switch seg {
+		case "", ".":
+			// collapses duplicate slashes and drops "." segments
+		case "..":
+			if len(segments) > 0 {
+				segments = segments[:len(segments)-1]
+			}
+		default:
+			segments = append(segments, seg)
+		}
+	}
+	cleaned := "/" + strings.Join(segments, "/")
+	if p[len(p)-1] == '/' && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+// hasRoute reports whether some method is registered for path, trimmed of
+// leading/trailing slashes, across both the static map and the tree.
+func (r *Router) hasRoute(path string) bool {
+	if _, ok := r.static[path]; ok {
+		return true
+	}
+	if rt, _, release := r.lookup(path); rt != nil {
+		release()
+		return true
+	}
+	return false
+}
+
+// tryCanonical attempts to recover an otherwise-404 request by trying a
+// canonical form of the path: collapsing "." / ".." and duplicate
+// slashes, adding or removing a trailing slash, and finally a
+// case-insensitive match. path arrives trimmed of leading and trailing
+// slashes, so the trailing-slash step does not recover a path that only
+// differs from a route by a trailing slash (such a path has already
+// matched). If a cleaned or trailing-slash form has a registered route,
+// it either redirects the client there or rewrites req.URL.Path in place
+// and re-dispatches on the same round trip, per Router.RedirectCleanPath;
+// a case-insensitive match is always redirected. Either way it returns
+// true. It returns false if no canonical form matches, leaving the caller
+// to respond 404.
+func (r *Router) tryCanonical(w http.ResponseWriter, req *http.Request, path string) bool {
+	if !r.CleanPath && !r.RedirectTrailingSlash {
+		return false
+	}
+
+	if r.CleanPath {
+		if cleaned := CleanPath("/" + path); strings.Trim(cleaned, "/") != path {
+			if trimmed := strings.Trim(cleaned, "/"); r.hasRoute(trimmed) {
+				return r.redirectOrContinue(w, req, cleaned)
+			}
+		}
+	}
+
+	if r.RedirectTrailingSlash {
+		if strings.HasSuffix(path, "/") {
+			if trimmed := strings.TrimSuffix(path, "/"); r.hasRoute(trimmed) {
+				return r.redirectOrContinue(w, req, "/"+trimmed)
+			}
+		} else if r.hasRoute(path + "/") {
+			return r.redirectOrContinue(w, req, "/"+path+"/")
+		}
+	}
+
+	if r.CleanPath {
+		if lower := strings.ToLower(path); lower != path && r.hasRoute(lower) {
+			r.redirect(w, req, "/"+lower)
+			return true
+		}
+	}
+
+	return false
+}
+
+// redirectOrContinue implements the RedirectCleanPath switch: redirect the
+// client to canonicalPath, or silently rewrite req.URL.Path to it and
+// re-run dispatch on the same request.
+func (r *Router) redirectOrContinue(w http.ResponseWriter, req *http.Request, canonicalPath string) bool {
+	if r.RedirectCleanPath {
+		r.redirect(w, req, canonicalPath)
+		return true
+	}
+	req.URL.Path = canonicalPath
+	r.staticCode(w, req)
+	return true
+}
+
+// redirect sends the client to path with a 301 (GET/HEAD) or 308
+// (everything else, since those must not silently change method/body).
+func (r *Router) redirect(w http.ResponseWriter, req *http.Request, path string) {
+	u := *req.URL
+	u.Path = path
+	code := http.StatusMovedPermanently
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		code = http.StatusPermanentRedirect
+	}
+	http.Redirect(w, req, u.String(), code)
+}
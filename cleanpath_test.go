@@ -0,0 +1,97 @@
+package blitzhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCleanPath(t *testing.T) {
+	cases := map[string]string{
+		"":                  "/",
+		"/":                 "/",
+		"//users//42":       "/users/42",
+		"/users/./42":       "/users/42",
+		"/users/../admin":   "/admin",
+		"/../../etc/passwd": "/etc/passwd",
+		"/users/42/":        "/users/42/",
+	}
+	for in, want := range cases {
+		if got := CleanPath(in); got != want {
+			t.Errorf("CleanPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCleanPathRedirects(t *testing.T) {
+	r := New()
+	r.CleanPath = true
+	r.RedirectCleanPath = true
+	r.GET("/users/42", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "//users//42", nil))
+
+	if rec.Code != http.StatusMovedPermanently {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusMovedPermanently)
+	}
+	if loc := rec.Header().Get("Location"); loc != "/users/42" {
+		t.Fatalf("Location = %q, want /users/42", loc)
+	}
+}
+
+func TestCleanPathRewritesInPlaceWhenRedirectDisabled(t *testing.T) {
+	r := New()
+	r.CleanPath = true
+	var gotPath string
+	r.GET("/users/42", func(w http.ResponseWriter, req *http.Request) {
+		gotPath = req.URL.Path
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "//users//42", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if gotPath != "/users/42" {
+		t.Fatalf("handler saw path %q, want /users/42", gotPath)
+	}
+}
+
+func TestCleanPathRedirectUsesPermanentRedirectForNonGet(t *testing.T) {
+	r := New()
+	r.CleanPath = true
+	r.RedirectCleanPath = true
+	r.POST("/users/42", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "//users//42", nil))
+
+	if rec.Code != http.StatusPermanentRedirect {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusPermanentRedirect)
+	}
+}
+
+func TestCleanPathCaseInsensitiveFallback(t *testing.T) {
+	r := New()
+	r.CleanPath = true
+	r.GET("/users/42", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/Users/42", nil))
+
+	if rec.Code != http.StatusMovedPermanently {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusMovedPermanently)
+	}
+	if loc := rec.Header().Get("Location"); loc != "/users/42" {
+		t.Fatalf("Location = %q, want /users/42", loc)
+	}
+}
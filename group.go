@@ -1,7 +1,6 @@
 package blitzhttp
 
 import (
-	"net/http"
 	"strings"
 )
 
@@ -22,32 +21,32 @@ func (r *Router) Group(prefix string, mws ...Middleware) *Group {
 }
 
 // GET registers a GET route
-func (g *Group) GET(path string, handler http.HandlerFunc, mws ...Middleware) {
+func (g *Group) GET(path string, handler interface{}, mws ...Middleware) {
 	g.addRoute(mGet, path, handler, mws...)
 }
 
 // POST registers a POST route
-func (g *Group) POST(path string, handler http.HandlerFunc, mws ...Middleware) {
+func (g *Group) POST(path string, handler interface{}, mws ...Middleware) {
 	g.addRoute(mPost, path, handler, mws...)
 }
 
 // PUT registers a PUT route
-func (g *Group) PUT(path string, handler http.HandlerFunc, mws ...Middleware) {
+func (g *Group) PUT(path string, handler interface{}, mws ...Middleware) {
 	g.addRoute(mPut, path, handler, mws...)
 }
 
 // DELETE registers a DELETE route
-func (g *Group) DELETE(path string, handler http.HandlerFunc, mws ...Middleware) {
+func (g *Group) DELETE(path string, handler interface{}, mws ...Middleware) {
 	g.addRoute(mDelete, path, handler, mws...)
 }
 
 // PATCH registers a PATCH route
-func (g *Group) PATCH(path string, handler http.HandlerFunc, mws ...Middleware) {
+func (g *Group) PATCH(path string, handler interface{}, mws ...Middleware) {
 	g.addRoute(mPatch, path, handler, mws...)
 }
 
 // ANY registers a route for all methods
-func (g *Group) ANY(path string, handler http.HandlerFunc, mws ...Middleware) {
+func (g *Group) ANY(path string, handler interface{}, mws ...Middleware) {
 	if path == "*" {
 		g.addCatchAll(handler, mws...)
 		return
@@ -57,12 +56,12 @@ func (g *Group) ANY(path string, handler http.HandlerFunc, mws ...Middleware) {
 	}
 }
 
-func (g *Group) addRoute(method int, path string, handler http.HandlerFunc, mws ...Middleware) {
+func (g *Group) addRoute(method int, path string, handler interface{}, mws ...Middleware) {
 	mws = append(g.mws, mws...)
 	g.router.addRoute(method, g.prefix+"/"+strings.Trim(path, "/"), handler, mws...)
 }
 
-func (g *Group) addCatchAll(handler http.HandlerFunc, mws ...Middleware) {
+func (g *Group) addCatchAll(handler interface{}, mws ...Middleware) {
 	mws = append(g.mws, mws...)
 	g.router.addCatchAll(handler, mws...)
 }
@@ -0,0 +1,28 @@
+// Package middleware holds net/http middleware that isn't tied to
+// blitzhttp's router, so it can wrap any http.Handler.
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/NazmulIslamFahim/blitzhttp"
+)
+
+// CleanPath canonicalizes the request path before calling next, redirecting
+// instead of calling next if it changes: 301 for GET/HEAD, 308 for every
+// other method, since those must not silently change method or body.
+func CleanPath(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cleaned := blitzhttp.CleanPath(r.URL.Path); cleaned != r.URL.Path {
+			u := *r.URL
+			u.Path = cleaned
+			code := http.StatusMovedPermanently
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				code = http.StatusPermanentRedirect
+			}
+			http.Redirect(w, r, u.String(), code)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
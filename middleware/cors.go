@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// CORSOptions configures CORS
+type CORSOptions struct {
+	// AllowedOrigins allows "*", an exact origin, or "*.example.com"
+	AllowedOrigins []string
+	// AllowedMethods lists the methods allowed in a preflight response
+	AllowedMethods []string
+	// AllowedHeaders lists the headers allowed in a preflight response; if
+	// empty, the request's own Access-Control-Request-Headers is echoed back
+	AllowedHeaders []string
+	// ExposedHeaders lists response headers exposed to client-side JS
+	ExposedHeaders []string
+	// AllowCredentials sets Access-Control-Allow-Credentials: true; the
+	// allowed origin is then always echoed back literally, never as "*"
+	AllowCredentials bool
+	// MaxAge is how long (in seconds) a preflight may be cached; 0 omits it
+	MaxAge int
+}
+
+// CORS returns middleware implementing cross-origin resource sharing per
+// opts, answering real preflights and emitting Access-Control-Allow-*
+// headers only once the request's Origin has matched AllowedOrigins.
+func CORS(opts CORSOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			allowed := origin != "" && originAllowed(opts.AllowedOrigins, origin)
+
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Add("Vary", "Origin")
+				if opts.AllowCredentials {
+					w.Header().Set("Access-Control-Allow-Credentials", "true")
+				}
+				if len(opts.ExposedHeaders) > 0 {
+					w.Header().Set("Access-Control-Expose-Headers", strings.Join(opts.ExposedHeaders, ", "))
+				}
+			}
+
+			isPreflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			if !isPreflight {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if allowed {
+				if len(opts.AllowedMethods) > 0 {
+					w.Header().Set("Access-Control-Allow-Methods", strings.Join(opts.AllowedMethods, ", "))
+				}
+				if len(opts.AllowedHeaders) > 0 {
+					w.Header().Set("Access-Control-Allow-Headers", strings.Join(opts.AllowedHeaders, ", "))
+				} else if reqHeaders := r.Header.Get("Access-Control-Request-Headers"); reqHeaders != "" {
+					w.Header().Set("Access-Control-Allow-Headers", reqHeaders)
+				}
+				if opts.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", strconv.Itoa(opts.MaxAge))
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// originAllowed reports whether origin matches one of the configured
+// patterns, matching a "*.domain" wildcard against the origin's host.
+func originAllowed(patterns []string, origin string) bool {
+	host := origin
+	if u, err := url.Parse(origin); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	for _, pattern := range patterns {
+		switch {
+		case pattern == "*":
+			return true
+		case pattern == origin:
+			return true
+		case strings.HasPrefix(pattern, "*."):
+			domain := pattern[2:]
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
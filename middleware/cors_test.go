@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NazmulIslamFahim/blitzhttp"
+)
+
+func TestCORSSimpleRequestAllowedOrigin(t *testing.T) {
+	mw := CORS(CORSOptions{AllowedOrigins: []string{"https://example.com"}})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rec := httptest.NewRecorder()
+	mw(next).ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want https://example.com", got)
+	}
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestCORSOriginNotAllowed(t *testing.T) {
+	mw := CORS(CORSOptions{AllowedOrigins: []string{"https://example.com"}})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://evil.example")
+	rec := httptest.NewRecorder()
+	mw(next).ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want empty", got)
+	}
+}
+
+func TestCORSWildcardSubdomain(t *testing.T) {
+	mw := CORS(CORSOptions{AllowedOrigins: []string{"*.example.com"}})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://api.example.com")
+	rec := httptest.NewRecorder()
+	mw(next).ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://api.example.com" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want https://api.example.com", got)
+	}
+}
+
+func TestCORSPreflightAnswersWithoutCallingNext(t *testing.T) {
+	called := false
+	mw := CORS(CORSOptions{
+		AllowedOrigins: []string{"https://example.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		MaxAge:         600,
+	})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+
+	req := httptest.NewRequest(http.MethodOptions, "/", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	rec := httptest.NewRecorder()
+	mw(next).ServeHTTP(rec, req)
+
+	if called {
+		t.Fatal("preflight must not reach next")
+	}
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST" {
+		t.Fatalf("Access-Control-Allow-Methods = %q, want GET, POST", got)
+	}
+	if got := rec.Header().Get("Access-Control-Max-Age"); got != "600" {
+		t.Fatalf("Access-Control-Max-Age = %q, want 600", got)
+	}
+}
+
+func TestCORSOrdinaryOptionsIsNotTreatedAsPreflight(t *testing.T) {
+	called := false
+	mw := CORS(CORSOptions{AllowedOrigins: []string{"*"}})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodOptions, "/", nil)
+	rec := httptest.NewRecorder()
+	mw(next).ServeHTTP(rec, req)
+
+	if !called {
+		t.Fatal("an OPTIONS request without Access-Control-Request-Method should reach next")
+	}
+}
+
+func TestCORSCredentialsNeverPairedWithWildcardOrigin(t *testing.T) {
+	mw := CORS(CORSOptions{AllowedOrigins: []string{"*"}, AllowCredentials: true})
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://example.com")
+	rec := httptest.NewRecorder()
+	mw(next).ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want the literal origin, not *", got)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Fatalf("Access-Control-Allow-Credentials = %q, want true", got)
+	}
+}
+
+// TestCORSGroupScopedMiddlewareAnswersPreflight is a regression test: CORS
+// attached at the Group level must see preflight requests, not just the
+// router's global middleware chain.
+func TestCORSGroupScopedMiddlewareAnswersPreflight(t *testing.T) {
+	r := blitzhttp.New()
+	api := r.Group("/api", CORS(CORSOptions{AllowedOrigins: []string{"https://example.com"}}))
+	api.GET("/widgets", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/widgets", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://example.com" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want https://example.com", got)
+	}
+}
@@ -0,0 +1,63 @@
+package blitzhttp
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Mount attaches h under prefix, stripping prefix before delegating
+// (http.StripPrefix semantics). A *Router is spliced into the tree
+// directly instead of being dispatched through a nested ServeHTTP.
+func (r *Router) Mount(prefix string, h http.Handler, mws ...Middleware) {
+	prefix = strings.Trim(prefix, "/")
+
+	if sub, ok := h.(*Router); ok {
+		r.spliceMount(prefix, sub, mws...)
+		return
+	}
+
+	mounted := http.StripPrefix("/"+prefix, h)
+	path := prefix + "/*"
+	for i := mGet; i <= mPatch; i++ {
+		r.addRoute(i, path, mounted, mws...)
+	}
+}
+
+// Mount attaches h under prefix, relative to the group
+func (g *Group) Mount(prefix string, h http.Handler, mws ...Middleware) {
+	mws = append(g.mws, mws...)
+	g.router.Mount(g.prefix+"/"+strings.Trim(prefix, "/"), h, mws...)
+}
+
+// spliceMount copies every route registered on sub into r, under prefix
+func (r *Router) spliceMount(prefix string, sub *Router, mws ...Middleware) {
+	for subPath, rt := range sub.static {
+		r.spliceRoute(joinMountPath(prefix, subPath), rt, mws...)
+	}
+	if sub.tree != nil {
+		sub.tree.walkPaths("", func(subPath string, rt *route) {
+			r.spliceRoute(joinMountPath(prefix, subPath), rt, mws...)
+		})
+	}
+	if sub.catchAll != nil {
+		r.spliceRoute(prefix+"/*", sub.catchAll, mws...)
+	}
+}
+
+// spliceRoute re-registers rt's already-composed handlers under path on r
+func (r *Router) spliceRoute(path string, rt *route, mws ...Middleware) {
+	for i, h := range rt.handlers {
+		if h != nil {
+			r.addRoute(i, path, h.handler, mws...)
+		}
+	}
+}
+
+// joinMountPath maps subPath (a route on the mounted router) to its path
+// under prefix on the parent; an empty subPath is sub's own root route.
+func joinMountPath(prefix, subPath string) string {
+	if subPath == "" {
+		return prefix
+	}
+	return prefix + "/" + subPath
+}
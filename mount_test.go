@@ -0,0 +1,39 @@
+package blitzhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMountRootRoute(t *testing.T) {
+	sub := New()
+	sub.GET("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	r := New()
+	r.Mount("/api", sub)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /api: got status %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestMountRootRouteDoesNotCatchSuffixes(t *testing.T) {
+	sub := New()
+	sub.GET("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	r := New()
+	r.Mount("/api", sub)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/whatever", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("GET /api/whatever: got status %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
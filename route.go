@@ -19,37 +19,63 @@ func (rt *route) getHandler(method string) *routeHandler {
 		return rt.handlers[mDelete]
 	case http.MethodPatch:
 		return rt.handlers[mPatch]
+	case http.MethodOptions:
+		return rt.handlers[mOptions]
 	}
 	return nil
 }
 
-// GET registers a GET route
-func (r *Router) GET(path string, handler http.HandlerFunc, mws ...Middleware) {
+// methodNames maps the mGet..mPatch indices to their http.Method* name,
+// the source of truth for both the 405 Allow header and OPTIONS.
+var methodNames = [mAny + 1]string{
+	mGet:    http.MethodGet,
+	mPost:   http.MethodPost,
+	mPut:    http.MethodPut,
+	mDelete: http.MethodDelete,
+	mPatch:  http.MethodPatch,
+}
+
+// allowedMethods returns the http methods actually registered on rt, in
+// mGet..mPatch order.
+func (rt *route) allowedMethods() []string {
+	methods := make([]string, 0, mAny)
+	for i, h := range rt.handlers {
+		if h != nil && methodNames[i] != "" {
+			methods = append(methods, methodNames[i])
+		}
+	}
+	return methods
+}
+
+// GET registers a GET route. handler may be an http.HandlerFunc, a
+// func(*Context) error, a func(w, r, *T) for any struct T, or any other
+// shape registered via RegisterHandlerType.
+func (r *Router) GET(path string, handler interface{}, mws ...Middleware) {
 	r.addRoute(mGet, path, handler, mws...)
 }
 
 // POST registers a POST route
-func (r *Router) POST(path string, handler http.HandlerFunc, mws ...Middleware) {
+func (r *Router) POST(path string, handler interface{}, mws ...Middleware) {
 	r.addRoute(mPost, path, handler, mws...)
 }
 
 // PUT registers a PUT route
-func (r *Router) PUT(path string, handler http.HandlerFunc, mws ...Middleware) {
+func (r *Router) PUT(path string, handler interface{}, mws ...Middleware) {
 	r.addRoute(mPut, path, handler, mws...)
 }
 
 // DELETE registers a DELETE route
-func (r *Router) DELETE(path string, handler http.HandlerFunc, mws ...Middleware) {
+func (r *Router) DELETE(path string, handler interface{}, mws ...Middleware) {
 	r.addRoute(mDelete, path, handler, mws...)
 }
 
 // PATCH registers a PATCH route
-func (r *Router) PATCH(path string, handler http.HandlerFunc, mws ...Middleware) {
+func (r *Router) PATCH(path string, handler interface{}, mws ...Middleware) {
 	r.addRoute(mPatch, path, handler, mws...)
 }
 
 // ANY registers a route for all methods
-func (r *Router) ANY(path string, handler http.HandlerFunc, mws ...Middleware) {
+func (r *Router) ANY(path string, handler interface{}, mws ...Middleware) {
 	if path == "*" {
 		r.addCatchAll(handler, mws...)
 		return
@@ -59,62 +85,93 @@ func (r *Router) ANY(path string, handler http.HandlerFunc, mws ...Middleware) {
 	}
 }
 
-func (r *Router) addRoute(method int, path string, handler http.HandlerFunc, mws ...Middleware) {
+func (r *Router) addRoute(method int, path string, handler interface{}, mws ...Middleware) {
 	path = strings.Trim(path, "/")
-	isWild := strings.HasSuffix(path, "*")
-	isParam := strings.Contains(path, ":")
-	if isWild {
-		path = strings.TrimSuffix(path, "*")
-	}
+	h := adaptHandler(handler)
 
-	var routes map[string]*route
-	switch {
-	case isWild:
-		routes = r.wildcards
-	case isParam:
-		routes = r.params
-	default:
-		routes = r.static
-	}
-
-	if _, exists := routes[path]; !exists {
-		routes[path] = &route{}
+	var rt *route
+	if isDynamicPath(path) {
+		rt = r.insert(path)
+	} else {
+		rt = r.static[path]
+		if rt == nil {
+			rt = &route{}
+			r.static[path] = rt
+		}
 	}
 
-	rt := routes[path]
-	rt.isParam = isParam
-	rt.isWild = isWild
 	mws = append(r.globalMWs, mws...)
-	rt.handlers[method] = &routeHandler{handler: composeHandler(handler, mws...)}
+	rt.handlers[method] = &routeHandler{handler: composeHandler(h, mws...)}
+	// Every route gets a default OPTIONS handler through the same mws chain
+	// as its other methods, so group/route-scoped middleware (e.g. CORS)
+	// sees preflight requests like it sees any other method.
+	if method != mOptions {
+		rt.handlers[mOptions] = &routeHandler{handler: composeHandler(http.HandlerFunc(rt.serveOptions), mws...)}
+	}
 	r.compileStatic()
 }
 
 // addCatchAll registers a catch-all route
-func (r *Router) addCatchAll(handler http.HandlerFunc, mws ...Middleware) {
+func (r *Router) addCatchAll(handler interface{}, mws ...Middleware) {
 	if r.catchAll == nil {
 		r.catchAll = &route{}
 	}
+	h := adaptHandler(handler)
 	mws = append(r.globalMWs, mws...)
 	for i := mGet; i <= mPatch; i++ {
-		r.catchAll.handlers[i] = &routeHandler{handler: composeHandler(handler, mws...)}
+		r.catchAll.handlers[i] = &routeHandler{handler: composeHandler(h, mws...)}
 	}
+	r.catchAll.handlers[mOptions] = &routeHandler{handler: composeHandler(http.HandlerFunc(r.catchAll.serveOptions), mws...)}
+}
+
+// serveOptions answers an OPTIONS request with an Allow header listing rt's
+// currently registered methods. It's installed as rt's default OPTIONS
+// handler so preflight requests flow through the same per-route middleware
+// chain as every other method instead of bypassing it.
+func (rt *route) serveOptions(w http.ResponseWriter, req *http.Request) {
+	allowed := append(rt.allowedMethods(), http.MethodOptions)
+	w.Header().Set("Allow", strings.Join(allowed, ","))
+	w.WriteHeader(http.StatusNoContent)
 }
 
-// compileStatic generates a static dispatch function
+// compileStatic rebuilds the dispatch closure ServeHTTP calls on every
+// request. Static, parameterized/wildcard and catch-all matching all live
+// in this one closure so Group.addRoute and Router.addRoute feed a single
+// dispatch path instead of ServeHTTP and the "compiled" path drifting apart.
 func (r *Router) compileStatic() {
-	if len(r.static) == 0 {
-		r.staticCode = nil
-		return
-	}
 	r.staticCode = func(w http.ResponseWriter, req *http.Request) {
 		path := strings.Trim(req.URL.Path, "/")
+
+		// Exact match
 		if rt, ok := r.static[path]; ok {
 			if h := rt.getHandler(req.Method); h != nil {
 				h.handler.ServeHTTP(w, req)
 				return
 			}
+			if r.methodNotAllowed(w, req, rt) {
+				return
+			}
+		}
+
+		// Parameterized and wildcard match
+		if rt, params, release := r.lookup(path); rt != nil {
+			if h := rt.getHandler(req.Method); h != nil {
+				ctx := req.Context()
+				if len(params) > 0 {
+					ctx = context.WithValue(ctx, paramsKey, params)
+				}
+				h.handler.ServeHTTP(w, req.WithContext(ctx))
+				release()
+				return
+			}
+			handled := r.methodNotAllowed(w, req, rt)
+			release()
+			if handled {
+				return
+			}
 		}
-		// Check catch-all for unmatched paths
+
+		// Catch-all match
 		if r.catchAll != nil {
 			if h := r.catchAll.getHandler(req.Method); h != nil {
 				ctx := context.WithValue(req.Context(), paramsKey, path)
@@ -122,10 +179,46 @@ func (r *Router) compileStatic() {
 				return
 			}
 		}
+
+		// Nothing matched as-is; see if a canonicalized path does.
+		if r.tryCanonical(w, req, path) {
+			return
+		}
+
+		// No route at all for this path; fall back to the router-wide
+		// default OPTIONS responder instead of 404ing a preflight.
+		if req.Method == http.MethodOptions {
+			r.optionsHandler.ServeHTTP(w, req)
+			return
+		}
+
 		http.NotFound(w, req)
 	}
 }
 
+// methodNotAllowed responds 405 with an Allow header listing rt's
+// registered methods, if Router.HandleMethodNotAllowed is enabled and rt
+// actually has any. It returns whether it wrote a response.
+func (r *Router) methodNotAllowed(w http.ResponseWriter, req *http.Request, rt *route) bool {
+	if !r.HandleMethodNotAllowed {
+		return false
+	}
+	allowed := rt.allowedMethods()
+	if len(allowed) == 0 {
+		return false
+	}
+	w.Header().Set("Allow", strings.Join(allowed, ","))
+	w.WriteHeader(http.StatusMethodNotAllowed)
+	return true
+}
+
+// serveOptions is the fallback OPTIONS responder for a path with no route
+// at all; a matched route answers through rt.serveOptions instead.
+func (r *Router) serveOptions(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Allow", http.MethodOptions)
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // composeHandler combines handler with middlewares
 func composeHandler(handler http.Handler, mws ...Middleware) http.Handler {
 	for i := len(mws) - 1; i >= 0; i-- {
@@ -0,0 +1,72 @@
+package blitzhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMethodNotAllowedSetsAllowHeader(t *testing.T) {
+	r := New()
+	r.GET("/users/:id", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	r.POST("/users/:id", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/users/42", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+	if allow := rec.Header().Get("Allow"); allow != "GET,POST" {
+		t.Fatalf("Allow = %q, want GET,POST", allow)
+	}
+}
+
+func TestMethodNotAllowedDisabledFallsThroughTo404(t *testing.T) {
+	r := New()
+	r.HandleMethodNotAllowed = false
+	r.GET("/users/:id", func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/users/42", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestOptionsReflectsRegisteredMethods(t *testing.T) {
+	r := New()
+	r.GET("/users/:id", func(w http.ResponseWriter, req *http.Request) {})
+	r.POST("/users/:id", func(w http.ResponseWriter, req *http.Request) {})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/users/42", nil))
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if allow := rec.Header().Get("Allow"); allow != "GET,POST,OPTIONS" {
+		t.Fatalf("Allow = %q, want GET,POST,OPTIONS", allow)
+	}
+}
+
+func TestOptionsOnUnknownPathUsesFallbackResponder(t *testing.T) {
+	r := New()
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/nope", nil))
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if allow := rec.Header().Get("Allow"); allow != http.MethodOptions {
+		t.Fatalf("Allow = %q, want OPTIONS", allow)
+	}
+}
@@ -1,116 +1,60 @@
 package blitzhttp
 
 import (
-	"context"
 	"net/http"
-	"strings"
 )
 
 // Router is the main router
 type Router struct {
-	static     map[string]*route                        // Exact path routes
-	params     map[string]*route                        // Parameterized routes (e.g., /users/:id)
-	wildcards  map[string]*route                        // Wildcard routes (e.g., /files/*)
-	catchAll   *route                                   // Catch-all route (e.g., *)
-	globalMWs  []Middleware                             // Global middlewares
-	staticCode func(http.ResponseWriter, *http.Request) // Compiled static routes
+	static         map[string]*route                        // Exact path routes
+	tree           *treeNode                                // Parameterized and wildcard routes (e.g., /users/:id, /files/*)
+	catchAll       *route                                   // Catch-all route (e.g., *)
+	globalMWs      []Middleware                             // Global middlewares
+	staticCode     func(http.ResponseWriter, *http.Request) // Compiled dispatch (static, tree, catch-all)
+	optionsHandler http.Handler                             // Fallback OPTIONS responder for paths with no route at all
+
+	// CleanPath enables recovering an otherwise-404 request by trying a
+	// canonicalized form of the path (collapsed slashes, resolved "."/"..",
+	// case-insensitive match) before giving up.
+	CleanPath bool
+	// RedirectCleanPath, when CleanPath finds a match on a canonical path
+	// that differs from the request path, sends the client a redirect to
+	// it. When false, the router instead rewrites req.URL.Path in place
+	// and serves the request on the same round trip. A match found only by
+	// the case-insensitive fallback is always redirected.
+	RedirectCleanPath bool
+	// RedirectTrailingSlash enables the trailing-slash step of the
+	// canonical-path fallback. Routes are matched with leading and trailing
+	// slashes trimmed, so a path that only differs from a registered route
+	// by a trailing slash already matches that route and is served without
+	// a redirect.
+	RedirectTrailingSlash bool
+	// HandleMethodNotAllowed responds 405 with an Allow header when a path
+	// matches a route but not for the request method. Defaults to true;
+	// set to false to fall through to a 404 instead, matching pre-405
+	// behavior.
+	HandleMethodNotAllowed bool
 }
 
 // route represents a path's handlers
 type route struct {
 	handlers [mAny + 1]*routeHandler // Method-specific handlers
-	isParam  bool                    // Has :param
-	isWild   bool                    // Has * wildcard
 }
 
 // New creates a Router
 func New() *Router {
 	r := &Router{
-		static:    make(map[string]*route),
-		params:    make(map[string]*route),
-		wildcards: make(map[string]*route),
+		static:                 make(map[string]*route),
+		HandleMethodNotAllowed: true,
 	}
 	r.compileStatic()
+	r.compileOptions()
 	return r
 }
 
 // ServeHTTP handles requests
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	if req.Method == http.MethodOptions {
-		w.Header().Set("Allow", "GET,POST,PUT,DELETE,PATCH,OPTIONS")
-		w.WriteHeader(http.StatusNoContent)
-		return
-	}
-
-	path := strings.Trim(req.URL.Path, "/")
-	if r.staticCode != nil {
-		r.staticCode(w, req)
-		return
-	}
-
-	// Exact match
-	if rt, ok := r.static[path]; ok {
-		if h := rt.getHandler(req.Method); h != nil {
-			h.handler.ServeHTTP(w, req)
-			return
-		}
-	}
-
-	// // Parameterized match
-	// for p, rt := range r.params {
-	// 	if params := matchParam(path, p); params != "" {
-	// 		if h := rt.getHandler(req.Method); h != nil {
-	// 			ctx := context.WithValue(req.Context(), paramsKey, params)
-	// 			h.handler.ServeHTTP(w, req.WithContext(ctx))
-	// 			return
-	// 		}
-	// 	}
-	// }
-
-	// // Wildcard match
-	// for p, rt := range r.wildcards {
-	// 	if strings.HasPrefix(path, p[:len(p)-1]) {
-	// 		if h := rt.getHandler(req.Method); h != nil {
-	// 			params := path[len(p):]
-	// 			ctx := context.WithValue(req.Context(), paramsKey, params)
-	// 			h.handler.ServeHTTP(w, req.WithContext(ctx))
-	// 			return
-	// 		}
-	// 	}
-	// }
-
-	// Catch-all match
-	if r.catchAll != nil {
-		if h := r.catchAll.getHandler(req.Method); h != nil {
-			ctx := context.WithValue(req.Context(), paramsKey, path)
-			h.handler.ServeHTTP(w, req.WithContext(ctx))
-			return
-		}
-	}
-
-	http.NotFound(w, req)
-}
-
-// matchParam checks if path matches a parameterized pattern
-func matchParam(path, pattern string) string {
-	if len(path) < len(pattern) {
-		return ""
-	}
-	if pattern == path {
-		return ""
-	}
-	pParts := strings.Split(pattern, "/")
-	rParts := strings.Split(path, "/")
-	if len(pParts) != len(rParts) {
-		return ""
-	}
-	for i, p := range pParts {
-		if p == rParts[i] || p[0] == ':' {
-			continue
-		}
-		return ""
-	}
-	return strings.Join(rParts, "/")
+	r.staticCode(w, req)
 }
 
 // Use adds global middlewares
@@ -121,21 +65,33 @@ func (r *Router) Use(mws ...Middleware) {
 
 // recomposeHandlers updates all handlers with global middlewares
 func (r *Router) recomposeHandlers() {
-	for _, routes := range []map[string]*route{r.static, r.params, r.wildcards} {
-		for _, rt := range routes {
-			for i, h := range rt.handlers {
-				if h != nil {
-					rt.handlers[i].handler = composeHandler(h.handler, r.globalMWs...)
-				}
-			}
-		}
+	for _, rt := range r.static {
+		recomposeRoute(rt, r.globalMWs)
+	}
+	if r.tree != nil {
+		r.tree.walk(func(rt *route) {
+			recomposeRoute(rt, r.globalMWs)
+		})
 	}
 	if r.catchAll != nil {
-		for i, h := range r.catchAll.handlers {
-			if h != nil {
-				r.catchAll.handlers[i].handler = composeHandler(h.handler, r.globalMWs...)
-			}
-		}
+		recomposeRoute(r.catchAll, r.globalMWs)
 	}
 	r.compileStatic()
+	r.compileOptions()
+}
+
+// compileOptions rebuilds the fallback OPTIONS handler used when a path
+// matches no route at all; a matched route answers OPTIONS through its own
+// handlers[mOptions] instead, so per-route/group middleware sees it too.
+func (r *Router) compileOptions() {
+	r.optionsHandler = composeHandler(http.HandlerFunc(r.serveOptions), r.globalMWs...)
+}
+
+// recomposeRoute rewraps every registered method handler on rt with mws
+func recomposeRoute(rt *route, mws []Middleware) {
+	for i, h := range rt.handlers {
+		if h != nil {
+			rt.handlers[i].handler = composeHandler(h.handler, mws...)
+		}
+	}
 }
@@ -0,0 +1,194 @@
+package blitzhttp
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// nodeType identifies how a treeNode matches a path segment
+type nodeType uint8
+
+const (
+	ntStatic nodeType = iota
+	ntParam
+	ntCatchAll
+)
+
+// treeNode is one path segment of the routing tree
+type treeNode struct {
+	segment   string
+	nType     nodeType
+	paramName string // set for ntParam/ntCatchAll nodes
+	children  map[string]*treeNode
+	param     *treeNode
+	catchAll  *treeNode
+	route     *route
+}
+
+// paramsPool recycles the []Param slices produced by lookup
+var paramsPool = sync.Pool{
+	New: func() interface{} {
+		s := make(Params, 0, 8)
+		return &s
+	},
+}
+
+// insert walks (and grows) the tree for path, returning the route at the leaf
+func (r *Router) insert(path string) *route {
+	if r.tree == nil {
+		r.tree = &treeNode{}
+	}
+	n := r.tree
+	rest := path
+	for rest != "" {
+		seg := rest
+		if idx := strings.IndexByte(rest, '/'); idx >= 0 {
+			seg, rest = rest[:idx], rest[idx+1:]
+		} else {
+			rest = ""
+		}
+		if seg == "" {
+			continue
+		}
+		switch seg[0] {
+		case ':':
+			name := seg[1:]
+			if n.param == nil {
+				n.param = &treeNode{segment: seg, nType: ntParam, paramName: name}
+			} else if n.param.paramName != name {
+				panic(fmt.Sprintf("blitzhttp: conflicting param names %q and %q on the same path segment", n.param.paramName, name))
+			}
+			n = n.param
+		case '*':
+			if rest != "" {
+				panic(fmt.Sprintf("blitzhttp: %q: no path segments allowed after a wildcard (%q)", path, seg))
+			}
+			name := seg[1:]
+			if n.catchAll == nil {
+				n.catchAll = &treeNode{segment: seg, nType: ntCatchAll, paramName: name}
+			} else if n.catchAll.paramName != name {
+				panic(fmt.Sprintf("blitzhttp: conflicting wildcard names %q and %q on the same path segment", n.catchAll.paramName, name))
+			}
+			n = n.catchAll
+		default:
+			if n.children == nil {
+				n.children = make(map[string]*treeNode)
+			}
+			child, ok := n.children[seg]
+			if !ok {
+				child = &treeNode{segment: seg, nType: ntStatic}
+				n.children[seg] = child
+			}
+			n = child
+		}
+	}
+	if n.route == nil {
+		n.route = &route{}
+	}
+	return n.route
+}
+
+// lookup walks the tree for path, collecting params into a slice borrowed
+// from paramsPool; the caller must call release once done with params.
+func (r *Router) lookup(path string) (rt *route, params Params, release func()) {
+	if r.tree == nil {
+		return nil, nil, nil
+	}
+	pp := paramsPool.Get().(*Params)
+	*pp = (*pp)[:0]
+
+	n := r.tree
+	rest := path
+	for rest != "" {
+		seg := rest
+		if idx := strings.IndexByte(rest, '/'); idx >= 0 {
+			seg, rest = rest[:idx], rest[idx+1:]
+		} else {
+			rest = ""
+		}
+		if seg == "" {
+			continue
+		}
+		switch {
+		case n.children != nil && n.children[seg] != nil:
+			n = n.children[seg]
+		case n.param != nil:
+			*pp = append(*pp, Param{Key: n.param.paramName, Value: seg})
+			n = n.param
+		case n.catchAll != nil:
+			value := seg
+			if rest != "" {
+				value = seg + "/" + rest
+			}
+			*pp = append(*pp, Param{Key: n.catchAll.paramName, Value: value})
+			n = n.catchAll
+			rest = ""
+		default:
+			paramsPool.Put(pp)
+			return nil, nil, nil
+		}
+	}
+
+	if n.route == nil {
+		paramsPool.Put(pp)
+		return nil, nil, nil
+	}
+	return n.route, *pp, func() { paramsPool.Put(pp) }
+}
+
+// walk visits every route reachable from n, including n's own
+func (n *treeNode) walk(fn func(*route)) {
+	if n.route != nil {
+		fn(n.route)
+	}
+	for _, c := range n.children {
+		c.walk(fn)
+	}
+	if n.param != nil {
+		n.param.walk(fn)
+	}
+	if n.catchAll != nil {
+		n.catchAll.walk(fn)
+	}
+}
+
+// walkPaths visits every route reachable from n along with its full path
+func (n *treeNode) walkPaths(prefix string, fn func(path string, rt *route)) {
+	if n.route != nil {
+		fn(prefix, n.route)
+	}
+	for seg, c := range n.children {
+		c.walkPaths(joinSegment(prefix, seg), fn)
+	}
+	if n.param != nil {
+		n.param.walkPaths(joinSegment(prefix, n.param.segment), fn)
+	}
+	if n.catchAll != nil {
+		n.catchAll.walkPaths(joinSegment(prefix, n.catchAll.segment), fn)
+	}
+}
+
+func joinSegment(prefix, seg string) string {
+	if prefix == "" {
+		return seg
+	}
+	return prefix + "/" + seg
+}
+
+// isDynamicPath reports whether path contains a :param or *wildcard segment.
+func isDynamicPath(path string) bool {
+	rest := path
+	for rest != "" {
+		seg := rest
+		if idx := strings.IndexByte(rest, '/'); idx >= 0 {
+			seg, rest = rest[:idx], rest[idx+1:]
+		} else {
+			rest = ""
+		}
+		if seg != "" && (seg[0] == ':' || seg[0] == '*') {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package blitzhttp
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestTreeStaticParamWildcardPrecedence(t *testing.T) {
+	r := New()
+	r.insert("users/me")
+	r.insert("users/:id")
+	r.insert("files/*path")
+
+	if rt, params, release := r.lookup("users/me"); rt == nil || len(params) != 0 {
+		t.Fatalf("users/me: want static match with no params, got rt=%v params=%v", rt, params)
+	} else {
+		release()
+	}
+
+	rt, params, release := r.lookup("users/42")
+	if rt == nil {
+		t.Fatal("users/42: want a match")
+	}
+	if got := params.Get("id"); got != "42" {
+		t.Fatalf("users/42: id param = %q, want 42", got)
+	}
+	release()
+
+	rt, params, release = r.lookup("files/a/b/c")
+	if rt == nil {
+		t.Fatal("files/a/b/c: want a match")
+	}
+	if got := params.Get("path"); got != "a/b/c" {
+		t.Fatalf("files/a/b/c: path param = %q, want a/b/c", got)
+	}
+	release()
+}
+
+func TestTreeLookupMiss(t *testing.T) {
+	r := New()
+	r.insert("users/:id")
+
+	if rt, _, _ := r.lookup("posts/1"); rt != nil {
+		t.Fatal("posts/1: want no match")
+	}
+}
+
+func TestTreeConflictingParamNamesPanics(t *testing.T) {
+	r := New()
+	r.insert("users/:id")
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("want a panic for conflicting param names on the same segment")
+		}
+	}()
+	r.insert("users/:slug")
+}
+
+func TestTreeConflictingWildcardNamesPanics(t *testing.T) {
+	r := New()
+	r.insert("files/*path")
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("want a panic for conflicting wildcard names on the same segment")
+		}
+	}()
+	r.insert("files/*rest")
+}
+
+func TestTreeSegmentAfterWildcardPanics(t *testing.T) {
+	r := New()
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("want a panic for a path segment after a wildcard")
+		}
+	}()
+	r.insert("users/*rest/profile")
+}
+
+func TestTreeParamsPoolReuseAcrossConcurrentLookups(t *testing.T) {
+	r := New()
+	r.insert("users/:id")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				rt, params, release := r.lookup("users/42")
+				if rt == nil || params.Get("id") != "42" {
+					t.Errorf("users/42: want id=42, got %v", params)
+				}
+				release()
+			}
+		}()
+	}
+	wg.Wait()
+}
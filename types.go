@@ -2,6 +2,8 @@ package blitzhttp
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 // Middleware wraps a handler
@@ -12,8 +14,63 @@ type paramsKeyType struct{}
 
 var paramsKey = paramsKeyType{}
 
-// GetParams retrieves route parameters
+// Param is a single named route parameter, e.g. {Key: "id", Value: "42"}
+// for a route registered as /users/:id.
+type Param struct {
+	Key   string
+	Value string
+}
+
+// Params is the set of named parameters matched for the current request,
+// in the order their segments appear in the route pattern. Lookups are a
+// linear scan, which is fine for the handful of params a route normally
+// has and keeps the common case allocation-free.
+type Params []Param
+
+// Get returns the value of the first parameter named key, or "" if there
+// is no such parameter.
+func (p Params) Get(key string) string {
+	for _, kv := range p {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return ""
+}
+
+// RouteParams returns the named route parameters matched for r.
+func RouteParams(r *http.Request) Params {
+	params, _ := r.Context().Value(paramsKey).(Params)
+	return params
+}
+
+// URLParam returns the value of the route parameter named key, or "" if
+// it isn't present (e.g. wrong name, or the route has no params).
+func URLParam(r *http.Request, key string) string {
+	return RouteParams(r).Get(key)
+}
+
+// URLParamInt returns the value of the route parameter named key parsed
+// as an int. It returns an error if the parameter is missing or not a
+// valid integer.
+func URLParamInt(r *http.Request, key string) (int, error) {
+	return strconv.Atoi(URLParam(r, key))
+}
+
+// GetParams retrieves route parameters as the legacy joined-path string
+// ("/" separated parameter values, in route order).
+//
+// Deprecated: use URLParam, URLParamInt, or RouteParams instead. GetParams
+// is kept for one release to ease migration off the single joined-string
+// params value and will be removed afterward.
 func GetParams(r *http.Request) string {
+	if params, ok := r.Context().Value(paramsKey).(Params); ok {
+		parts := make([]string, len(params))
+		for i, p := range params {
+			parts[i] = p.Value
+		}
+		return strings.Join(parts, "/")
+	}
 	if params, ok := r.Context().Value(paramsKey).(string); ok {
 		return params
 	}
@@ -27,6 +84,7 @@ const (
 	mPut
 	mDelete
 	mPatch
+	mOptions
 	mAny
 )
 